@@ -0,0 +1,180 @@
+package logrus
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+)
+
+// errAsyncWriterClosed is returned by Write once Close has been called.
+var errAsyncWriterClosed = errors.New("async writer is closed")
+
+// OverflowPolicy controls what happens when an async writer's bounded buffer is full.
+type OverflowPolicy int
+
+const (
+	// Block waits for room in the buffer, applying backpressure to the caller.
+	Block OverflowPolicy = iota
+	// DropOldest discards the oldest buffered entry to make room for the new one.
+	DropOldest
+	// DropNewest discards the incoming entry, keeping the buffer as-is.
+	DropNewest
+)
+
+const defaultBufferSize = 1000
+
+// asyncWriter decouples callers from a potentially slow underlying io.Writer by buffering writes
+// on a bounded channel and flushing them from a single background goroutine.
+type asyncWriter struct {
+	next    io.Writer
+	policy  OverflowPolicy
+	queue   chan []byte
+	flushes chan chan struct{}
+	closed  chan struct{}
+
+	// mu guards stopped, and is held for the duration of any send on queue, so that Close
+	// cannot close queue out from under a concurrent Write (which would panic).
+	mu      sync.RWMutex
+	stopped bool
+
+	// closing is closed by Close before it waits on mu, so a Write blocked sending on queue under
+	// the Block policy (which would otherwise hold mu's read lock forever if next.Write hangs and
+	// the background goroutine stalls) can observe shutdown and return without waiting for mu.
+	closing   chan struct{}
+	closeOnce sync.Once
+}
+
+func newAsyncWriter(next io.Writer, bufferSize int, policy OverflowPolicy) *asyncWriter {
+	if bufferSize <= 0 {
+		bufferSize = defaultBufferSize
+	}
+	w := &asyncWriter{
+		next:    next,
+		policy:  policy,
+		queue:   make(chan []byte, bufferSize),
+		flushes: make(chan chan struct{}),
+		closed:  make(chan struct{}),
+		closing: make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+func (w *asyncWriter) run() {
+	defer close(w.closed)
+	for {
+		select {
+		case p, ok := <-w.queue:
+			if !ok {
+				return
+			}
+			_, _ = w.next.Write(p)
+		case done := <-w.flushes:
+			w.drainQueue()
+			close(done)
+		}
+	}
+}
+
+func (w *asyncWriter) drainQueue() {
+	for {
+		select {
+		case p := <-w.queue:
+			_, _ = w.next.Write(p)
+		default:
+			return
+		}
+	}
+}
+
+// Write enqueues a copy of p for asynchronous delivery, applying the configured overflow policy
+// when the buffer is full. It never blocks on the underlying writer. Under the Block policy it
+// may wait for room in the buffer, but returns errAsyncWriterClosed rather than blocking forever
+// if Close is called while it waits. It also returns errAsyncWriterClosed once Close has already
+// completed.
+func (w *asyncWriter) Write(p []byte) (int, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if w.stopped {
+		return 0, errAsyncWriterClosed
+	}
+
+	buf := make([]byte, len(p))
+	copy(buf, p)
+
+	select {
+	case w.queue <- buf:
+		return len(p), nil
+	default:
+	}
+
+	switch w.policy {
+	case DropNewest:
+		return len(p), nil
+	case DropOldest:
+		select {
+		case <-w.queue:
+		default:
+		}
+		select {
+		case w.queue <- buf:
+		default:
+		}
+		return len(p), nil
+	default: // Block
+		select {
+		case w.queue <- buf:
+			return len(p), nil
+		case <-w.closing:
+			return 0, errAsyncWriterClosed
+		}
+	}
+}
+
+// Flush blocks until all currently-queued entries have been written, or ctx is done. It returns
+// errAsyncWriterClosed if the writer has already been closed.
+func (w *asyncWriter) Flush(ctx context.Context) error {
+	w.mu.RLock()
+	stopped := w.stopped
+	w.mu.RUnlock()
+	if stopped {
+		return errAsyncWriterClosed
+	}
+
+	done := make(chan struct{})
+	select {
+	case w.flushes <- done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops accepting new writes, drains and stops the background goroutine, and waits for it
+// to exit. It is safe to call more than once.
+func (w *asyncWriter) Close() error {
+	w.closeOnce.Do(func() {
+		close(w.closing)
+	})
+
+	w.mu.Lock()
+	alreadyStopped := w.stopped
+	if !alreadyStopped {
+		w.stopped = true
+		close(w.queue)
+	}
+	w.mu.Unlock()
+
+	if !alreadyStopped {
+		<-w.closed
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package logrus
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAsyncWriter_WriteAfterClose(t *testing.T) {
+	var buf bytes.Buffer
+	w := newAsyncWriter(&buf, 10, Block)
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	if _, err := w.Write([]byte("hello")); !errors.Is(err, errAsyncWriterClosed) {
+		t.Fatalf("expected errAsyncWriterClosed, got %v", err)
+	}
+}
+
+func TestAsyncWriter_ConcurrentWriteAndClose(t *testing.T) {
+	var buf bytes.Buffer
+	w := newAsyncWriter(&buf, 1, Block)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = w.Write([]byte("x"))
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_ = w.Close()
+	}()
+
+	wg.Wait()
+}
+
+func TestAsyncWriter_Flush(t *testing.T) {
+	var buf bytes.Buffer
+	w := newAsyncWriter(&buf, 10, Block)
+	defer w.Close()
+
+	for i := 0; i < 5; i++ {
+		if _, err := w.Write([]byte("x")); err != nil {
+			t.Fatalf("Write returned error: %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := w.Flush(ctx); err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+
+	if buf.Len() != 5 {
+		t.Fatalf("expected 5 bytes written after flush, got %d", buf.Len())
+	}
+}
+
+// hangingWriter never returns from Write until release is closed, simulating a stalled sink
+// (e.g. a hung network mount).
+type hangingWriter struct {
+	release chan struct{}
+}
+
+func (h *hangingWriter) Write(p []byte) (int, error) {
+	<-h.release
+	return len(p), nil
+}
+
+func TestAsyncWriter_CloseUnblocksBlockedWrite(t *testing.T) {
+	hw := &hangingWriter{release: make(chan struct{})}
+
+	w := newAsyncWriter(hw, 1, Block)
+
+	// Let the background goroutine pick up a first write and hang in hw.Write (simulating a
+	// stalled sink), then fill the buffer so a subsequent Write has nowhere to go and must take
+	// the Block branch.
+	if _, err := w.Write([]byte("a")); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if _, err := w.Write([]byte("b")); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+
+	blocked := make(chan error, 1)
+	go func() {
+		_, err := w.Write([]byte("c"))
+		blocked <- err
+	}()
+
+	// Give the blocked Write a moment to actually reach the Block branch before closing.
+	time.Sleep(10 * time.Millisecond)
+
+	closeDone := make(chan error, 1)
+	go func() {
+		closeDone <- w.Close()
+	}()
+
+	// The blocked Write must return as soon as Close begins, even though the background
+	// goroutine is still stuck delivering to the hung sink and Close itself hasn't returned yet.
+	select {
+	case err := <-blocked:
+		if !errors.Is(err, errAsyncWriterClosed) {
+			t.Fatalf("expected blocked Write to return errAsyncWriterClosed, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("blocked Write did not return once Close was called")
+	}
+
+	// Once the sink recovers, Close can finish draining and return.
+	close(hw.release)
+	select {
+	case err := <-closeDone:
+		if err != nil {
+			t.Fatalf("Close returned error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Close did not return after the sink recovered")
+	}
+}
+
+func TestAsyncWriter_DropPolicies(t *testing.T) {
+	var buf bytes.Buffer
+	w := newAsyncWriter(&buf, 1, DropNewest)
+	defer w.Close()
+
+	for i := 0; i < 3; i++ {
+		if _, err := w.Write([]byte("x")); err != nil {
+			t.Fatalf("Write returned error: %v", err)
+		}
+	}
+}
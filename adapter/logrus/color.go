@@ -0,0 +1,39 @@
+package logrus
+
+import (
+	"io"
+	"os"
+)
+
+// ColorMode controls whether formatted output is allowed to include ANSI color escapes.
+type ColorMode int
+
+const (
+	// ColorAuto enables colors only when output is attached to a terminal, honoring NO_COLOR
+	// and CLICOLOR.
+	ColorAuto ColorMode = iota
+	// ColorAlways forces colors on regardless of whether output is a terminal.
+	ColorAlways
+	// ColorNever disables colors regardless of whether output is a terminal.
+	ColorNever
+)
+
+// resolveColors determines whether the formatter should emit ANSI colors for the given output,
+// respecting mode and the NO_COLOR/CLICOLOR environment variable conventions.
+func resolveColors(mode ColorMode, output io.Writer) bool {
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	if os.Getenv("CLICOLOR") == "0" {
+		return false
+	}
+
+	return isTerminal(output)
+}
@@ -0,0 +1,87 @@
+package logrus
+
+import (
+	iface "github.com/anchore/go-logger"
+	"github.com/sirupsen/logrus"
+)
+
+var _ iface.MessageLogger = (*sampledEntry)(nil)
+
+// sampledEntry adapts a logrus.Entry (as returned by WithFields) to iface.MessageLogger while
+// applying the owning logger's sampler, so log calls made through a WithFields chain are
+// rate-limited the same as calls made directly on the logger.
+type sampledEntry struct {
+	entry             *logrus.Entry
+	sampler           *sampler
+	captureCallerInfo bool
+}
+
+func (e *sampledEntry) Tracef(format string, args ...interface{}) {
+	if e.sampler != nil && !e.sampler.allow(sampleKey("trace", format, e.captureCallerInfo)) {
+		return
+	}
+	e.entry.Tracef(format, args...)
+}
+
+func (e *sampledEntry) Debugf(format string, args ...interface{}) {
+	if e.sampler != nil && !e.sampler.allow(sampleKey("debug", format, e.captureCallerInfo)) {
+		return
+	}
+	e.entry.Debugf(format, args...)
+}
+
+func (e *sampledEntry) Infof(format string, args ...interface{}) {
+	if e.sampler != nil && !e.sampler.allow(sampleKey("info", format, e.captureCallerInfo)) {
+		return
+	}
+	e.entry.Infof(format, args...)
+}
+
+func (e *sampledEntry) Warnf(format string, args ...interface{}) {
+	if e.sampler != nil && !e.sampler.allow(sampleKey("warning", format, e.captureCallerInfo)) {
+		return
+	}
+	e.entry.Warnf(format, args...)
+}
+
+func (e *sampledEntry) Errorf(format string, args ...interface{}) {
+	if e.sampler != nil && !e.sampler.allow(sampleKey("error", format, e.captureCallerInfo)) {
+		return
+	}
+	e.entry.Errorf(format, args...)
+}
+
+func (e *sampledEntry) Trace(args ...interface{}) {
+	if e.sampler != nil && !e.sampler.allow(sampleKeyCaller("trace", callerSkipDirect)) {
+		return
+	}
+	e.entry.Trace(args...)
+}
+
+func (e *sampledEntry) Debug(args ...interface{}) {
+	if e.sampler != nil && !e.sampler.allow(sampleKeyCaller("debug", callerSkipDirect)) {
+		return
+	}
+	e.entry.Debug(args...)
+}
+
+func (e *sampledEntry) Info(args ...interface{}) {
+	if e.sampler != nil && !e.sampler.allow(sampleKeyCaller("info", callerSkipDirect)) {
+		return
+	}
+	e.entry.Info(args...)
+}
+
+func (e *sampledEntry) Warn(args ...interface{}) {
+	if e.sampler != nil && !e.sampler.allow(sampleKeyCaller("warning", callerSkipDirect)) {
+		return
+	}
+	e.entry.Warn(args...)
+}
+
+func (e *sampledEntry) Error(args ...interface{}) {
+	if e.sampler != nil && !e.sampler.allow(sampleKeyCaller("error", callerSkipDirect)) {
+		return
+	}
+	e.entry.Error(args...)
+}
@@ -0,0 +1,52 @@
+package logrus
+
+import (
+	iface "github.com/anchore/go-logger"
+	"github.com/sirupsen/logrus"
+)
+
+// hookAdapter translates an iface.Hook into a logrus.Hook so it can be registered against the
+// underlying logrus logger without leaking logrus types back out through the iface package.
+type hookAdapter struct {
+	hook iface.Hook
+}
+
+func (h hookAdapter) Levels() []logrus.Level {
+	levels := make([]logrus.Level, 0, len(h.hook.Levels()))
+	for _, l := range h.hook.Levels() {
+		levels = append(levels, getLogLevel(l))
+	}
+	return levels
+}
+
+func (h hookAdapter) Fire(entry *logrus.Entry) error {
+	return h.hook.Fire(iface.Entry{
+		Level:   getIfaceLevel(entry.Level),
+		Message: entry.Message,
+		Fields:  map[string]interface{}(entry.Data),
+		Time:    entry.Time,
+	})
+}
+
+func getIfaceLevel(level logrus.Level) iface.Level {
+	switch level {
+	case logrus.ErrorLevel:
+		return iface.ErrorLevel
+	case logrus.WarnLevel:
+		return iface.WarnLevel
+	case logrus.InfoLevel:
+		return iface.InfoLevel
+	case logrus.DebugLevel:
+		return iface.DebugLevel
+	case logrus.TraceLevel:
+		return iface.TraceLevel
+	}
+	return iface.DisabledLevel
+}
+
+// addHooks registers each of the given hooks against the logrus logger via hookAdapter.
+func addHooks(l *logrus.Logger, hooks []iface.Hook) {
+	for _, h := range hooks {
+		l.AddHook(hookAdapter{hook: h})
+	}
+}
@@ -1,6 +1,7 @@
 package logrus
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"io/fs"
@@ -27,6 +28,24 @@ type Config struct {
 	NoLock            bool
 	ForceColors       bool
 	ForceFormatting   bool
+	ColorMode         ColorMode
+	Async             bool
+	BufferSize        int
+	OverflowPolicy    OverflowPolicy
+	Hooks             []iface.Hook
+	MaxSizeMB         int
+	MaxBackups        int
+	MaxAgeDays        int
+	Compress          bool
+	LocalTime         bool
+	Sampling          *SamplingConfig
+}
+
+// WithHooks returns a copy of cfg with the given hooks appended, for use when constructing a
+// Config inline (e.g. logrus.DefaultConfig().WithHooks(mySyslogHook)).
+func (cfg Config) WithHooks(hooks ...iface.Hook) Config {
+	cfg.Hooks = append(cfg.Hooks, hooks...)
+	return cfg
 }
 
 func DefaultConfig() Config {
@@ -37,16 +56,22 @@ func DefaultConfig() Config {
 		Level:             iface.InfoLevel,
 		CaptureCallerInfo: false,
 		NoLock:            false,
-		ForceColors:       true,
+		ForceColors:       false,
 		ForceFormatting:   true,
+		ColorMode:         ColorAuto,
+		Async:             false,
+		BufferSize:        defaultBufferSize,
+		OverflowPolicy:    Block,
 	}
 }
 
 // logger contains all runtime values for using Logrus with the configured output target and input configuration values.
 type logger struct {
-	config Config
-	logger *logrus.Logger
-	output io.Writer
+	config  Config
+	logger  *logrus.Logger
+	output  io.Writer
+	async   *asyncWriter
+	sampler *sampler
 }
 
 // New creates a new entry with the given configuration
@@ -56,17 +81,17 @@ func New(cfg Config) (iface.Logger, error) {
 	var output io.Writer
 	switch {
 	case cfg.EnableConsole && cfg.FileLocation != "":
-		logFile, err := os.OpenFile(cfg.FileLocation, os.O_WRONLY|os.O_CREATE, defaultLogFilePermissions)
-		if err != nil {
-			return nil, fmt.Errorf("unable to setup log file: %w", err)
+		logFile := newRotatingFile(cfg.FileLocation, cfg, defaultLogFilePermissions)
+		if err := logFile.probeOpen(); err != nil {
+			return nil, err
 		}
 		output = io.MultiWriter(os.Stderr, logFile)
 	case cfg.EnableConsole:
 		output = os.Stderr
 	case cfg.FileLocation != "":
-		logFile, err := os.OpenFile(cfg.FileLocation, os.O_WRONLY|os.O_CREATE, defaultLogFilePermissions)
-		if err != nil {
-			return nil, fmt.Errorf("unable to setup log file: %w", err)
+		logFile := newRotatingFile(cfg.FileLocation, cfg, defaultLogFilePermissions)
+		if err := logFile.probeOpen(); err != nil {
+			return nil, err
 		}
 		output = logFile
 	default:
@@ -78,12 +103,19 @@ func New(cfg Config) (iface.Logger, error) {
 		return nil, fmt.Errorf("unknown log level %q", cfg.Level)
 	}
 
+	var async *asyncWriter
+	if cfg.Async {
+		async = newAsyncWriter(output, cfg.BufferSize, cfg.OverflowPolicy)
+		output = async
+	}
+
 	l.SetOutput(output)
 	l.SetLevel(level)
 	l.SetReportCaller(cfg.CaptureCallerInfo)
 	if cfg.NoLock {
 		l.SetNoLock()
 	}
+	addHooks(l, cfg.Hooks)
 
 	if cfg.Structured {
 		l.SetFormatter(&logrus.JSONFormatter{
@@ -93,17 +125,34 @@ func New(cfg Config) (iface.Logger, error) {
 			PrettyPrint:       false,
 		})
 	} else {
+		// Only treat stderr as a color candidate when it is the sole destination: when a
+		// FileLocation is also configured, the same formatted output is written to both via
+		// io.MultiWriter, and colorizing it would corrupt the file just as ForceColors used to.
+		colorStream := io.Writer(ioutil.Discard)
+		if cfg.EnableConsole && cfg.FileLocation == "" {
+			colorStream = os.Stderr
+		}
+		useColors := cfg.ForceColors || resolveColors(cfg.ColorMode, colorStream)
+
 		l.SetFormatter(&prefixed.TextFormatter{
 			TimestampFormat: "2006-01-02 15:04:05",
-			ForceColors:     cfg.ForceColors,
+			ForceColors:     useColors,
+			DisableColors:   !useColors,
 			ForceFormatting: cfg.ForceFormatting,
 		})
 	}
 
+	var smplr *sampler
+	if cfg.Sampling != nil {
+		smplr = newSampler(*cfg.Sampling)
+	}
+
 	return &logger{
-		config: cfg,
-		logger: l,
-		output: output,
+		config:  cfg,
+		logger:  l,
+		output:  output,
+		async:   async,
+		sampler: smplr,
 	}, nil
 }
 
@@ -125,61 +174,101 @@ func getLogLevel(level iface.Level) logrus.Level {
 
 // Tracef takes a formatted template string and template arguments for the trace logging level.
 func (l *logger) Tracef(format string, args ...interface{}) {
+	if l.sampler != nil && !l.sampler.allow(sampleKey("trace", format, l.config.CaptureCallerInfo)) {
+		return
+	}
 	l.logger.Tracef(format, args...)
 }
 
 // Debugf takes a formatted template string and template arguments for the debug logging level.
 func (l *logger) Debugf(format string, args ...interface{}) {
+	if l.sampler != nil && !l.sampler.allow(sampleKey("debug", format, l.config.CaptureCallerInfo)) {
+		return
+	}
 	l.logger.Debugf(format, args...)
 }
 
 // Infof takes a formatted template string and template arguments for the info logging level.
 func (l *logger) Infof(format string, args ...interface{}) {
+	if l.sampler != nil && !l.sampler.allow(sampleKey("info", format, l.config.CaptureCallerInfo)) {
+		return
+	}
 	l.logger.Infof(format, args...)
 }
 
 // Warnf takes a formatted template string and template arguments for the warning logging level.
 func (l *logger) Warnf(format string, args ...interface{}) {
+	if l.sampler != nil && !l.sampler.allow(sampleKey("warning", format, l.config.CaptureCallerInfo)) {
+		return
+	}
 	l.logger.Warnf(format, args...)
 }
 
 // Errorf takes a formatted template string and template arguments for the error logging level.
 func (l *logger) Errorf(format string, args ...interface{}) {
+	if l.sampler != nil && !l.sampler.allow(sampleKey("error", format, l.config.CaptureCallerInfo)) {
+		return
+	}
 	l.logger.Errorf(format, args...)
 }
 
 // Trace logs the given arguments at the trace logging level.
 func (l *logger) Trace(args ...interface{}) {
+	if l.sampler != nil && !l.sampler.allow(sampleKeyCaller("trace", callerSkipDirect)) {
+		return
+	}
 	l.logger.Trace(args...)
 }
 
 // Debug logs the given arguments at the debug logging level.
 func (l *logger) Debug(args ...interface{}) {
+	if l.sampler != nil && !l.sampler.allow(sampleKeyCaller("debug", callerSkipDirect)) {
+		return
+	}
 	l.logger.Debug(args...)
 }
 
 // Info logs the given arguments at the info logging level.
 func (l *logger) Info(args ...interface{}) {
+	if l.sampler != nil && !l.sampler.allow(sampleKeyCaller("info", callerSkipDirect)) {
+		return
+	}
 	l.logger.Info(args...)
 }
 
 // Warn logs the given arguments at the warning logging level.
 func (l *logger) Warn(args ...interface{}) {
+	if l.sampler != nil && !l.sampler.allow(sampleKeyCaller("warning", callerSkipDirect)) {
+		return
+	}
 	l.logger.Warn(args...)
 }
 
 // Error logs the given arguments at the error logging level.
 func (l *logger) Error(args ...interface{}) {
+	if l.sampler != nil && !l.sampler.allow(sampleKeyCaller("error", callerSkipDirect)) {
+		return
+	}
 	l.logger.Error(args...)
 }
 
 // WithFields returns a message entry with multiple key-value fields.
 func (l *logger) WithFields(fields ...interface{}) iface.MessageLogger {
-	return l.logger.WithFields(getFields(fields...))
+	return &sampledEntry{
+		entry:             l.logger.WithFields(getFields(fields...)),
+		sampler:           l.sampler,
+		captureCallerInfo: l.config.CaptureCallerInfo,
+	}
 }
 
+// Nested returns a logger scoped with additional fields. The returned logger shares this
+// logger's sampler, so subsystem loggers draw from the same sampling budget.
 func (l *logger) Nested(fields ...interface{}) iface.Logger {
-	return &nestedLogger{entry: l.logger.WithFields(getFields(fields...))}
+	return &nestedLogger{
+		entry:             l.logger.WithFields(getFields(fields...)),
+		sampler:           l.sampler,
+		captureCallerInfo: l.config.CaptureCallerInfo,
+	}
 }
 
 func (l *logger) SetOutput(writer io.Writer) {
@@ -191,12 +280,38 @@ func (l *logger) GetOutput() io.Writer {
 	return l.output
 }
 
+// Flush blocks until all entries buffered by an async writer have been delivered, or ctx is done.
+// It is a no-op when the logger is not running in async mode.
+func (l *logger) Flush(ctx context.Context) error {
+	if l.async == nil {
+		return nil
+	}
+	return l.async.Flush(ctx)
+}
+
+// Close stops any background delivery goroutine, waiting for buffered entries to drain first.
+// It is a no-op when the logger is not running in async mode.
+func (l *logger) Close() error {
+	if l.async == nil {
+		return nil
+	}
+	return l.async.Close()
+}
+
+// getFields builds a logrus.Fields from either iface.Field values (each contributing its own
+// key/value) or legacy untyped alternating key, value pairs.
 func getFields(fields ...interface{}) logrus.Fields {
 	f := make(logrus.Fields)
-	for i, val := range fields {
-		if i%2 != 0 {
-			f[fmt.Sprintf("%s", fields[i-1])] = val
+	for i := 0; i < len(fields); i++ {
+		if field, ok := fields[i].(iface.Field); ok {
+			f[field.Key] = field.Value
+			continue
+		}
+		if i+1 >= len(fields) {
+			break
 		}
+		f[fmt.Sprintf("%s", fields[i])] = fields[i+1]
+		i++
 	}
 	return f
 }
\ No newline at end of file
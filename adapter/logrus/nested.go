@@ -0,0 +1,114 @@
+package logrus
+
+import (
+	iface "github.com/anchore/go-logger"
+	"github.com/sirupsen/logrus"
+)
+
+var _ iface.Logger = (*nestedLogger)(nil)
+
+// nestedLogger is a Logger scoped to a logrus.Entry carrying a fixed set of fields, as returned
+// by logger.Nested.
+type nestedLogger struct {
+	entry             *logrus.Entry
+	sampler           *sampler
+	captureCallerInfo bool
+}
+
+// Tracef takes a formatted template string and template arguments for the trace logging level.
+func (l *nestedLogger) Tracef(format string, args ...interface{}) {
+	if l.sampler != nil && !l.sampler.allow(sampleKey("trace", format, l.captureCallerInfo)) {
+		return
+	}
+	l.entry.Tracef(format, args...)
+}
+
+// Debugf takes a formatted template string and template arguments for the debug logging level.
+func (l *nestedLogger) Debugf(format string, args ...interface{}) {
+	if l.sampler != nil && !l.sampler.allow(sampleKey("debug", format, l.captureCallerInfo)) {
+		return
+	}
+	l.entry.Debugf(format, args...)
+}
+
+// Infof takes a formatted template string and template arguments for the info logging level.
+func (l *nestedLogger) Infof(format string, args ...interface{}) {
+	if l.sampler != nil && !l.sampler.allow(sampleKey("info", format, l.captureCallerInfo)) {
+		return
+	}
+	l.entry.Infof(format, args...)
+}
+
+// Warnf takes a formatted template string and template arguments for the warning logging level.
+func (l *nestedLogger) Warnf(format string, args ...interface{}) {
+	if l.sampler != nil && !l.sampler.allow(sampleKey("warning", format, l.captureCallerInfo)) {
+		return
+	}
+	l.entry.Warnf(format, args...)
+}
+
+// Errorf takes a formatted template string and template arguments for the error logging level.
+func (l *nestedLogger) Errorf(format string, args ...interface{}) {
+	if l.sampler != nil && !l.sampler.allow(sampleKey("error", format, l.captureCallerInfo)) {
+		return
+	}
+	l.entry.Errorf(format, args...)
+}
+
+// Trace logs the given arguments at the trace logging level.
+func (l *nestedLogger) Trace(args ...interface{}) {
+	if l.sampler != nil && !l.sampler.allow(sampleKeyCaller("trace", callerSkipDirect)) {
+		return
+	}
+	l.entry.Trace(args...)
+}
+
+// Debug logs the given arguments at the debug logging level.
+func (l *nestedLogger) Debug(args ...interface{}) {
+	if l.sampler != nil && !l.sampler.allow(sampleKeyCaller("debug", callerSkipDirect)) {
+		return
+	}
+	l.entry.Debug(args...)
+}
+
+// Info logs the given arguments at the info logging level.
+func (l *nestedLogger) Info(args ...interface{}) {
+	if l.sampler != nil && !l.sampler.allow(sampleKeyCaller("info", callerSkipDirect)) {
+		return
+	}
+	l.entry.Info(args...)
+}
+
+// Warn logs the given arguments at the warning logging level.
+func (l *nestedLogger) Warn(args ...interface{}) {
+	if l.sampler != nil && !l.sampler.allow(sampleKeyCaller("warning", callerSkipDirect)) {
+		return
+	}
+	l.entry.Warn(args...)
+}
+
+// Error logs the given arguments at the error logging level.
+func (l *nestedLogger) Error(args ...interface{}) {
+	if l.sampler != nil && !l.sampler.allow(sampleKeyCaller("error", callerSkipDirect)) {
+		return
+	}
+	l.entry.Error(args...)
+}
+
+// WithFields returns a message entry with multiple key-value fields.
+func (l *nestedLogger) WithFields(fields ...interface{}) iface.MessageLogger {
+	return &sampledEntry{
+		entry:             l.entry.WithFields(getFields(fields...)),
+		sampler:           l.sampler,
+		captureCallerInfo: l.captureCallerInfo,
+	}
+}
+
+// Nested returns a logger scoped with additional fields, sharing this logger's sampling budget.
+func (l *nestedLogger) Nested(fields ...interface{}) iface.Logger {
+	return &nestedLogger{
+		entry:             l.entry.WithFields(getFields(fields...)),
+		sampler:           l.sampler,
+		captureCallerInfo: l.captureCallerInfo,
+	}
+}
@@ -0,0 +1,214 @@
+package logrus
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxSizeMB = 100
+	megabyte         = 1024 * 1024
+)
+
+// rotatingFile is a small, self-contained lumberjack-style io.Writer: it writes to a file until
+// it exceeds MaxSizeMB, then renames it aside (optionally gzip-compressing it) and opens a fresh
+// file in its place, pruning backups beyond MaxBackups or older than MaxAgeDays.
+//
+// hook.FileRotation (hook/file.go) duplicates this rotate/prune/backup-naming behavior for use
+// outside the logrus adapter. Keep the two in sync when changing either.
+type rotatingFile struct {
+	filename    string
+	maxSizeMB   int
+	maxBackups  int
+	maxAgeDays  int
+	compress    bool
+	localTime   bool
+	permissions os.FileMode
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+func newRotatingFile(filename string, cfg Config, permissions os.FileMode) *rotatingFile {
+	maxSizeMB := cfg.MaxSizeMB
+	if maxSizeMB <= 0 {
+		maxSizeMB = defaultMaxSizeMB
+	}
+	return &rotatingFile{
+		filename:    filename,
+		maxSizeMB:   maxSizeMB,
+		maxBackups:  cfg.MaxBackups,
+		maxAgeDays:  cfg.MaxAgeDays,
+		compress:    cfg.Compress,
+		localTime:   cfg.LocalTime,
+		permissions: permissions,
+	}
+}
+
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		if err := r.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	if r.size+int64(len(p)) > int64(r.maxSizeMB)*megabyte {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	if err != nil {
+		return n, fmt.Errorf("unable to write to log file: %w", err)
+	}
+	return n, nil
+}
+
+// probeOpen eagerly opens the underlying file if it isn't already, so that misconfiguration (a
+// bad path, permission denied, ...) is reported synchronously by New rather than surfacing only
+// on the first subsequent log call.
+func (r *rotatingFile) probeOpen() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file != nil {
+		return nil
+	}
+	return r.open()
+}
+
+func (r *rotatingFile) open() error {
+	f, err := os.OpenFile(r.filename, os.O_WRONLY|os.O_CREATE|os.O_APPEND, r.permissions)
+	if err != nil {
+		return fmt.Errorf("unable to open log file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("unable to stat log file: %w", err)
+	}
+	r.file = f
+	r.size = info.Size()
+	return nil
+}
+
+func (r *rotatingFile) rotate() error {
+	if r.file != nil {
+		_ = r.file.Close()
+	}
+
+	backup := r.backupName()
+	if err := os.Rename(r.filename, backup); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("unable to rotate log file: %w", err)
+	}
+
+	if r.compress {
+		go r.compressBackup(backup)
+	}
+
+	go r.prune()
+
+	return r.open()
+}
+
+func (r *rotatingFile) backupName() string {
+	now := time.Now()
+	if !r.localTime {
+		now = now.UTC()
+	}
+	ext := filepath.Ext(r.filename)
+	base := strings.TrimSuffix(r.filename, ext)
+	return fmt.Sprintf("%s-%s%s", base, now.Format("2006-01-02T15-04-05.000"), ext)
+}
+
+func (r *rotatingFile) compressBackup(name string) {
+	src, err := os.Open(name)
+	if err != nil {
+		return
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(name+".gz", os.O_CREATE|os.O_TRUNC|os.O_WRONLY, r.permissions)
+	if err != nil {
+		return
+	}
+	defer dst.Close()
+
+	gz := gzip.NewWriter(dst)
+	if _, err := io.Copy(gz, src); err != nil {
+		_ = gz.Close()
+		return
+	}
+	if err := gz.Close(); err != nil {
+		return
+	}
+	_ = os.Remove(name)
+}
+
+func (r *rotatingFile) prune() {
+	backups, err := r.listBackups()
+	if err != nil {
+		return
+	}
+
+	var toRemove []string
+	if r.maxBackups > 0 && len(backups) > r.maxBackups {
+		toRemove = append(toRemove, backups[r.maxBackups:]...)
+		backups = backups[:r.maxBackups]
+	}
+	if r.maxAgeDays > 0 {
+		cutoff := time.Now().AddDate(0, 0, -r.maxAgeDays)
+		for _, b := range backups {
+			info, err := os.Stat(b)
+			if err == nil && info.ModTime().Before(cutoff) {
+				toRemove = append(toRemove, b)
+			}
+		}
+	}
+
+	for _, b := range toRemove {
+		_ = os.Remove(b)
+	}
+}
+
+func (r *rotatingFile) listBackups() ([]string, error) {
+	ext := filepath.Ext(r.filename)
+	base := strings.TrimSuffix(filepath.Base(r.filename), ext)
+	dir := filepath.Dir(r.filename)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list log directory: %w", err)
+	}
+
+	var backups []string
+	for _, e := range entries {
+		name := e.Name()
+		if strings.HasPrefix(name, base+"-") {
+			backups = append(backups, filepath.Join(dir, name))
+		}
+	}
+
+	sort.Sort(sort.Reverse(sort.StringSlice(backups)))
+	return backups, nil
+}
+
+func (r *rotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	return r.file.Close()
+}
@@ -0,0 +1,100 @@
+package logrus
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// SamplingConfig rate-limits log calls by key (level plus format string, or level plus call site
+// when CaptureCallerInfo is set): the first Initial occurrences within each Tick window are
+// logged, then only 1 in every Thereafter. This bounds the cost of a tight loop that logs the
+// same message repeatedly, a well-known logrus operational pain point.
+type SamplingConfig struct {
+	Initial    int
+	Thereafter int
+	Tick       time.Duration
+}
+
+type sampleCount struct {
+	windowStart time.Time
+	n           int
+}
+
+// sampler is a keyed token-bucket shared by a logger and everything derived from it via Nested,
+// so subsystem loggers share the same sampling budget.
+type sampler struct {
+	cfg SamplingConfig
+
+	mu     sync.Mutex
+	counts map[string]*sampleCount
+}
+
+func newSampler(cfg SamplingConfig) *sampler {
+	if cfg.Tick <= 0 {
+		cfg.Tick = time.Second
+	}
+	if cfg.Thereafter <= 0 {
+		cfg.Thereafter = 1
+	}
+	return &sampler{cfg: cfg, counts: make(map[string]*sampleCount)}
+}
+
+// allow reports whether a log call keyed by key should proceed, advancing that key's counter. A
+// nil sampler always allows, so sampling is a strict opt-in.
+func (s *sampler) allow(key string) bool {
+	if s == nil {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	c, ok := s.counts[key]
+	if !ok || now.Sub(c.windowStart) >= s.cfg.Tick {
+		c = &sampleCount{windowStart: now}
+		s.counts[key] = c
+	}
+	c.n++
+
+	if c.n <= s.cfg.Initial {
+		return true
+	}
+	return (c.n-s.cfg.Initial)%s.cfg.Thereafter == 0
+}
+
+// Caller depths for runtime.Caller, relative to sampleKeyCaller itself, at which the application's
+// actual log call resolves. These differ because the formatted path (sampleKey) adds one more
+// stack frame than callers that invoke sampleKeyCaller directly.
+const (
+	// callerSkipDirect is for callers that invoke sampleKeyCaller directly, e.g.
+	// X.Trace -> sampleKeyCaller -> runtime.Caller.
+	callerSkipDirect = 2
+	// callerSkipFormatted is for sampleKey's own call into sampleKeyCaller, e.g.
+	// X.Tracef -> sampleKey -> sampleKeyCaller -> runtime.Caller.
+	callerSkipFormatted = 3
+)
+
+// sampleKey builds the key a sampler uses to bucket a log call: the call site when
+// captureCallerInfo is set, otherwise the level and format string. The format string (not the
+// rendered message) is used so that a single noisy call site is recognized as one key even when
+// its arguments vary between calls.
+func sampleKey(level, format string, captureCallerInfo bool) string {
+	if captureCallerInfo {
+		return sampleKeyCaller(level, callerSkipFormatted)
+	}
+	return level + "|" + format
+}
+
+// sampleKeyCaller builds a key from the call site skip frames up the stack. It is used for the
+// non-format log methods (Trace, Debug, ...), which have no format string to key on and whose
+// rendered message may vary between calls at the same call site, as well as by sampleKey for the
+// CaptureCallerInfo case.
+func sampleKeyCaller(level string, skip int) string {
+	if _, file, line, ok := runtime.Caller(skip); ok {
+		return fmt.Sprintf("%s|%s:%d", level, file, line)
+	}
+	return level
+}
@@ -0,0 +1,88 @@
+package logrus
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSampler_NilAlwaysAllows(t *testing.T) {
+	var s *sampler
+	for i := 0; i < 10; i++ {
+		if !s.allow("any") {
+			t.Fatalf("nil sampler should always allow")
+		}
+	}
+}
+
+func TestSampler_InitialThenThereafter(t *testing.T) {
+	s := newSampler(SamplingConfig{Initial: 2, Thereafter: 3, Tick: time.Minute})
+
+	got := make([]bool, 0, 8)
+	for i := 0; i < 8; i++ {
+		got = append(got, s.allow("key"))
+	}
+
+	// first 2 allowed, then every 3rd thereafter (positions 3 and 6 relative to the 3rd call).
+	want := []bool{true, true, false, false, true, false, false, true}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("call %d: got %v, want %v (full: %v)", i, got[i], want[i], got)
+		}
+	}
+}
+
+func TestSampler_DistinctKeysHaveIndependentBudgets(t *testing.T) {
+	s := newSampler(SamplingConfig{Initial: 1, Thereafter: 10, Tick: time.Minute})
+
+	if !s.allow("a") {
+		t.Fatalf("first call for key a should be allowed")
+	}
+	if !s.allow("b") {
+		t.Fatalf("first call for key b should be allowed")
+	}
+	if s.allow("a") {
+		t.Fatalf("second call for key a should be throttled")
+	}
+}
+
+func TestSampler_WindowResets(t *testing.T) {
+	s := newSampler(SamplingConfig{Initial: 1, Thereafter: 10, Tick: time.Millisecond})
+
+	if !s.allow("key") {
+		t.Fatalf("first call should be allowed")
+	}
+	if s.allow("key") {
+		t.Fatalf("second call within the same window should be throttled")
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	if !s.allow("key") {
+		t.Fatalf("call in a new window should be allowed again")
+	}
+}
+
+// callSiteA and callSiteB stand in for a Tracef/Debugf/... method that calls sampleKey, so with
+// captureCallerInfo the key resolves to the line that calls them, emulating application call sites.
+func callSiteA() string {
+	return sampleKey("trace", "doing %s", true)
+}
+
+func callSiteB() string {
+	return sampleKey("trace", "doing %s", true)
+}
+
+func TestSampleKey_CaptureCallerInfo_DistinctCallSitesDistinctKeys(t *testing.T) {
+	a := callSiteA()
+	b := callSiteB()
+
+	if a == b {
+		t.Fatalf("expected distinct call sites to produce distinct keys, both got %q", a)
+	}
+}
+
+func TestSampleKey_CaptureCallerInfo_SameCallSiteSameKey(t *testing.T) {
+	if callSiteA() != callSiteA() {
+		t.Fatalf("expected repeated calls from the same call site to produce the same key")
+	}
+}
@@ -0,0 +1,114 @@
+package logrus
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"runtime"
+
+	iface "github.com/anchore/go-logger"
+	"github.com/sirupsen/logrus"
+)
+
+// slogHandler adapts a logger constructed via New to the standard library's log/slog.Handler
+// interface, so callers can use Go 1.21+ structured logging while still routing through this
+// module's Config, hooks, and sinks. It also implements iface.Controller, so callers that
+// configured cfg.Async can still Flush/Close it on shutdown.
+type slogHandler struct {
+	impl  *logger
+	entry *logrus.Entry
+}
+
+var _ slog.Handler = (*slogHandler)(nil)
+var _ iface.Controller = (*slogHandler)(nil)
+
+// NewSlogHandler builds a slog.Handler backed by a logrus logger constructed from cfg. The
+// returned value also implements iface.Controller; callers running with cfg.Async should type
+// assert it to Flush/Close the async buffer on shutdown.
+func NewSlogHandler(cfg Config) (slog.Handler, error) {
+	l, err := New(cfg)
+	if err != nil {
+		return nil, err
+	}
+	impl, ok := l.(*logger)
+	if !ok {
+		return nil, fmt.Errorf("unexpected logger implementation %T", l)
+	}
+	return &slogHandler{impl: impl, entry: logrus.NewEntry(impl.logger)}, nil
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.impl.logger.IsLevelEnabled(slogToLogrusLevel(level))
+}
+
+func (h *slogHandler) SetOutput(writer io.Writer) {
+	h.impl.SetOutput(writer)
+}
+
+func (h *slogHandler) GetOutput() io.Writer {
+	return h.impl.GetOutput()
+}
+
+// Flush blocks until all entries buffered by an async writer have been delivered, or ctx is done.
+// It is a no-op when the handler is not running in async mode.
+func (h *slogHandler) Flush(ctx context.Context) error {
+	return h.impl.Flush(ctx)
+}
+
+// Close stops any background delivery goroutine, waiting for buffered entries to drain first. It
+// is a no-op when the handler is not running in async mode.
+func (h *slogHandler) Close() error {
+	return h.impl.Close()
+}
+
+func (h *slogHandler) Handle(_ context.Context, record slog.Record) error {
+	if h.impl.sampler != nil {
+		// With CaptureCallerInfo, key on the application's call site recorded by slog (record.PC);
+		// the stack frames here belong to log/slog, not the caller.
+		key := sampleKey(record.Level.String(), record.Message, false)
+		if h.impl.config.CaptureCallerInfo && record.PC != 0 {
+			frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+			key = fmt.Sprintf("%s|%s:%d", record.Level.String(), frame.File, frame.Line)
+		}
+		if !h.impl.sampler.allow(key) {
+			return nil
+		}
+	}
+
+	fields := make(logrus.Fields, record.NumAttrs())
+	record.Attrs(func(a slog.Attr) bool {
+		fields[a.Key] = a.Value.Any()
+		return true
+	})
+
+	h.entry.WithFields(fields).WithTime(record.Time).Log(slogToLogrusLevel(record.Level), record.Message)
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	fields := make(logrus.Fields, len(attrs))
+	for _, a := range attrs {
+		fields[a.Key] = a.Value.Any()
+	}
+	return &slogHandler{impl: h.impl, entry: h.entry.WithFields(fields)}
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	// logrus has no notion of attribute groups; record the group name as a single "group" field
+	// instead. Attribute keys are not prefixed with it, and a nested group replaces the outer one.
+	return &slogHandler{impl: h.impl, entry: h.entry.WithField("group", name)}
+}
+
+func slogToLogrusLevel(level slog.Level) logrus.Level {
+	switch {
+	case level >= slog.LevelError:
+		return logrus.ErrorLevel
+	case level >= slog.LevelWarn:
+		return logrus.WarnLevel
+	case level >= slog.LevelInfo:
+		return logrus.InfoLevel
+	default:
+		return logrus.DebugLevel
+	}
+}
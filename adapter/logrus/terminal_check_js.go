@@ -0,0 +1,10 @@
+//go:build js
+// +build js
+
+package logrus
+
+import "io"
+
+func isTerminal(w io.Writer) bool {
+	return false
+}
@@ -0,0 +1,10 @@
+//go:build appengine
+// +build appengine
+
+package logrus
+
+import "io"
+
+func isTerminal(w io.Writer) bool {
+	return false
+}
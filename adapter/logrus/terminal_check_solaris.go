@@ -0,0 +1,21 @@
+//go:build solaris && !appengine
+// +build solaris
+// +build !appengine
+
+package logrus
+
+import (
+	"io"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	_, err := unix.IoctlGetTermio(int(f.Fd()), unix.TCGETA)
+	return err == nil
+}
@@ -0,0 +1,15 @@
+package logger
+
+import (
+	"context"
+	"io"
+)
+
+// Controller is the set of methods exposed on a Logger for runtime management of the underlying
+// logging pipeline (where entries are written, and how that pipeline is drained on shutdown).
+type Controller interface {
+	SetOutput(writer io.Writer)
+	GetOutput() io.Writer
+	Flush(ctx context.Context) error
+	Close() error
+}
@@ -0,0 +1,76 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+// Field is a single typed key-value pair to attach to a log entry. It is an alternative to the
+// untyped alternating pairs accepted by WithFields, which cannot distinguish a key from a value
+// at compile time and silently drop a trailing, unpaired argument.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// String builds a Field holding a string value.
+func String(key, value string) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Int builds a Field holding an int value.
+func Int(key string, value int) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Duration builds a Field holding a time.Duration value.
+func Duration(key string, value time.Duration) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Any builds a Field holding an arbitrary value, for types without a dedicated constructor.
+func Any(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Err builds a Field named "error" for err. See ErrorField for how err is expanded.
+func Err(err error) Field {
+	return ErrorField("error", err)
+}
+
+// StackTracer is implemented by errors that carry a stack trace, matching the convention
+// popularized by github.com/pkg/errors.
+type StackTracer interface {
+	StackTrace() pkgerrors.StackTrace
+}
+
+// ErrorField builds a Field named key for err, unwrapping its errors.Is/As chain into a list of
+// causes and, when err (or a cause in its chain) implements StackTracer, attaching its stack
+// trace.
+func ErrorField(key string, err error) Field {
+	if err == nil {
+		return Field{Key: key, Value: nil}
+	}
+
+	value := map[string]interface{}{
+		"message": err.Error(),
+	}
+
+	var causes []string
+	for unwrapped := errors.Unwrap(err); unwrapped != nil; unwrapped = errors.Unwrap(unwrapped) {
+		causes = append(causes, unwrapped.Error())
+	}
+	if len(causes) > 0 {
+		value["causes"] = causes
+	}
+
+	var st StackTracer
+	if errors.As(err, &st) {
+		value["stack"] = fmt.Sprintf("%+v", st.StackTrace())
+	}
+
+	return Field{Key: key, Value: value}
+}
@@ -0,0 +1,20 @@
+package logger
+
+import "time"
+
+// Entry is a single log record as seen by a Hook, independent of any particular logging backend.
+type Entry struct {
+	Level   Level
+	Message string
+	Fields  map[string]interface{}
+	Time    time.Time
+}
+
+// Hook is a sink that observes log entries as they are emitted, without requiring callers to
+// depend on a particular logging backend (e.g. logrus) directly.
+type Hook interface {
+	// Levels returns the set of levels this hook should be invoked for.
+	Levels() []Level
+	// Fire is called with each entry at a level returned by Levels.
+	Fire(entry Entry) error
+}
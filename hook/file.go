@@ -0,0 +1,246 @@
+package hook
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	iface "github.com/anchore/go-logger"
+)
+
+const (
+	defaultFileRotationMaxSizeMB = 100
+	megabyte                     = 1024 * 1024
+)
+
+// FileRotation is a Hook that appends each entry as a line to a file, rotating it aside once it
+// exceeds MaxSizeMB; backups beyond MaxBackups, or older than MaxAgeDays, are pruned.
+//
+// This duplicates the lumberjack-style rotation adapter/logrus's rotatingFile (rotate.go)
+// implements, rather than sharing it: that type is internal to the logrus adapter, while this
+// hook is usable independent of which logging backend is in use. Keep the rotate/prune/backup
+// behavior of the two in sync when changing either.
+type FileRotation struct {
+	Filename   string
+	MaxSizeMB  int
+	MaxBackups int
+	MaxAgeDays int
+	Compress   bool
+	LocalTime  bool
+	levels     []iface.Level
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// NewFileRotation returns a Hook that fires for the given levels (defaulting to all levels).
+func NewFileRotation(filename string, maxSizeMB, maxBackups int, levels ...iface.Level) *FileRotation {
+	if maxSizeMB <= 0 {
+		maxSizeMB = defaultFileRotationMaxSizeMB
+	}
+	if len(levels) == 0 {
+		levels = []iface.Level{iface.ErrorLevel, iface.WarnLevel, iface.InfoLevel, iface.DebugLevel, iface.TraceLevel}
+	}
+	return &FileRotation{
+		Filename:   filename,
+		MaxSizeMB:  maxSizeMB,
+		MaxBackups: maxBackups,
+		levels:     levels,
+	}
+}
+
+// WithMaxAgeDays sets the maximum age of a rotated backup before it is pruned, in addition to any
+// MaxBackups limit. It returns f for inline construction, e.g.
+// NewFileRotation(...).WithMaxAgeDays(7).
+func (f *FileRotation) WithMaxAgeDays(days int) *FileRotation {
+	f.MaxAgeDays = days
+	return f
+}
+
+// WithCompress gzip-compresses rotated backups. It returns f for inline construction.
+func (f *FileRotation) WithCompress(compress bool) *FileRotation {
+	f.Compress = compress
+	return f
+}
+
+// WithLocalTime timestamps rotated backups using local time instead of UTC. It returns f for
+// inline construction.
+func (f *FileRotation) WithLocalTime(local bool) *FileRotation {
+	f.LocalTime = local
+	return f
+}
+
+func (f *FileRotation) Levels() []iface.Level {
+	return f.levels
+}
+
+func (f *FileRotation) Fire(entry iface.Entry) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return err
+		}
+	}
+
+	line := []byte(fmt.Sprintf("%s [%s] %s%s\n", entry.Time.Format(time.RFC3339), entry.Level, entry.Message, formatFields(entry.Fields)))
+	if f.size+int64(len(line)) > int64(f.MaxSizeMB)*megabyte {
+		if err := f.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := f.file.Write(line)
+	f.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("unable to write to log file: %w", err)
+	}
+	return nil
+}
+
+// formatFields renders entry fields as " key=value key2=value2" (in sorted key order, for
+// deterministic output), or "" when there are none.
+func formatFields(fields map[string]interface{}) string {
+	if len(fields) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		fmt.Fprintf(&b, " %s=%v", k, fields[k])
+	}
+	return b.String()
+}
+
+func (f *FileRotation) open() error {
+	file, err := os.OpenFile(f.Filename, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("unable to open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("unable to stat log file: %w", err)
+	}
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+func (f *FileRotation) rotate() error {
+	_ = f.file.Close()
+
+	ext := filepath.Ext(f.Filename)
+	base := strings.TrimSuffix(f.Filename, ext)
+	backup := f.backupName(base, ext)
+	if err := os.Rename(f.Filename, backup); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("unable to rotate log file: %w", err)
+	}
+
+	if f.Compress {
+		go f.compressBackup(backup)
+	}
+
+	go f.prune(base, ext)
+
+	return f.open()
+}
+
+func (f *FileRotation) backupName(base, ext string) string {
+	now := time.Now()
+	if !f.LocalTime {
+		now = now.UTC()
+	}
+	return fmt.Sprintf("%s-%s%s", base, now.Format("2006-01-02T15-04-05.000"), ext)
+}
+
+func (f *FileRotation) compressBackup(name string) {
+	src, err := os.Open(name)
+	if err != nil {
+		return
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(name+".gz", os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return
+	}
+	defer dst.Close()
+
+	gz := gzip.NewWriter(dst)
+	if _, err := io.Copy(gz, src); err != nil {
+		_ = gz.Close()
+		return
+	}
+	if err := gz.Close(); err != nil {
+		return
+	}
+	_ = os.Remove(name)
+}
+
+// prune removes backups beyond MaxBackups and, independently, backups older than MaxAgeDays. The
+// timestamp embedded in each backup's name (see backupName) is a fixed-width, zero-padded format,
+// so a plain lexicographic sort orders backups newest-first without parsing the timestamp out.
+func (f *FileRotation) prune(base, ext string) {
+	if f.MaxBackups <= 0 && f.MaxAgeDays <= 0 {
+		return
+	}
+
+	backups, err := f.listBackups(base, ext)
+	if err != nil {
+		return
+	}
+
+	var toRemove []string
+	if f.MaxBackups > 0 && len(backups) > f.MaxBackups {
+		toRemove = append(toRemove, backups[f.MaxBackups:]...)
+		backups = backups[:f.MaxBackups]
+	}
+	if f.MaxAgeDays > 0 {
+		cutoff := time.Now().AddDate(0, 0, -f.MaxAgeDays)
+		for _, b := range backups {
+			info, err := os.Stat(b)
+			if err == nil && info.ModTime().Before(cutoff) {
+				toRemove = append(toRemove, b)
+			}
+		}
+	}
+
+	for _, b := range toRemove {
+		_ = os.Remove(b)
+	}
+}
+
+func (f *FileRotation) listBackups(base, ext string) ([]string, error) {
+	dir := filepath.Dir(f.Filename)
+	prefix := filepath.Base(base) + "-"
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list log directory: %w", err)
+	}
+
+	var backups []string
+	for _, e := range entries {
+		name := e.Name()
+		if strings.HasPrefix(name, prefix) && (strings.HasSuffix(name, ext) || strings.HasSuffix(name, ext+".gz")) {
+			backups = append(backups, filepath.Join(dir, name))
+		}
+	}
+
+	sort.Sort(sort.Reverse(sort.StringSlice(backups)))
+	return backups, nil
+}
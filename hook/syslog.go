@@ -0,0 +1,48 @@
+//go:build !windows && !nacl && !plan9
+
+// Package hook provides built-in iface.Hook implementations for common cross-cutting sinks
+// (syslog, webhooks, file rotation) so callers don't need to depend on logrus directly.
+package hook
+
+import (
+	"fmt"
+	"log/syslog"
+
+	iface "github.com/anchore/go-logger"
+)
+
+// Syslog is a Hook that forwards entries to the local or remote syslog daemon.
+type Syslog struct {
+	writer *syslog.Writer
+	levels []iface.Level
+}
+
+// NewSyslog dials network (e.g. "udp", "tcp") and addr (network empty for the local syslog daemon) and
+// returns a Hook that fires for the given levels, defaulting to all levels when none are given.
+func NewSyslog(network, addr, tag string, levels ...iface.Level) (*Syslog, error) {
+	w, err := syslog.Dial(network, addr, syslog.LOG_INFO, tag)
+	if err != nil {
+		return nil, fmt.Errorf("unable to dial syslog: %w", err)
+	}
+	if len(levels) == 0 {
+		levels = []iface.Level{iface.ErrorLevel, iface.WarnLevel, iface.InfoLevel, iface.DebugLevel, iface.TraceLevel}
+	}
+	return &Syslog{writer: w, levels: levels}, nil
+}
+
+func (s *Syslog) Levels() []iface.Level {
+	return s.levels
+}
+
+func (s *Syslog) Fire(entry iface.Entry) error {
+	switch entry.Level {
+	case iface.ErrorLevel:
+		return s.writer.Err(entry.Message)
+	case iface.WarnLevel:
+		return s.writer.Warning(entry.Message)
+	case iface.DebugLevel, iface.TraceLevel:
+		return s.writer.Debug(entry.Message)
+	default:
+		return s.writer.Info(entry.Message)
+	}
+}
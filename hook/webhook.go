@@ -0,0 +1,71 @@
+package hook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	iface "github.com/anchore/go-logger"
+)
+
+// defaultWebhookTimeout bounds how long Fire waits on the endpoint. logrus fires hooks
+// synchronously on the caller's goroutine, so a hung endpoint and no timeout would block every
+// logged entry at the configured levels indefinitely.
+const defaultWebhookTimeout = 5 * time.Second
+
+// Webhook is a Hook that POSTs each entry as JSON to a configured URL, suitable for simple
+// alerting or remote log shipping integrations. Because logrus invokes hooks synchronously, Fire
+// blocks the calling goroutine for as long as the request takes, bounded by Client's timeout.
+type Webhook struct {
+	URL    string
+	Client *http.Client
+	levels []iface.Level
+}
+
+// NewWebhook returns a Hook that POSTs entries at the given levels (defaulting to Error and Warn)
+// to url, using an http.Client with a bounded timeout so a hung endpoint can't stall logging
+// indefinitely.
+func NewWebhook(url string, levels ...iface.Level) *Webhook {
+	if len(levels) == 0 {
+		levels = []iface.Level{iface.ErrorLevel, iface.WarnLevel}
+	}
+	return &Webhook{
+		URL:    url,
+		Client: &http.Client{Timeout: defaultWebhookTimeout},
+		levels: levels,
+	}
+}
+
+func (w *Webhook) Levels() []iface.Level {
+	return w.levels
+}
+
+func (w *Webhook) Fire(entry iface.Entry) error {
+	body, err := json.Marshal(struct {
+		Level   iface.Level            `json:"level"`
+		Message string                 `json:"message"`
+		Fields  map[string]interface{} `json:"fields,omitempty"`
+		Time    time.Time              `json:"time"`
+	}{
+		Level:   entry.Level,
+		Message: entry.Message,
+		Fields:  entry.Fields,
+		Time:    entry.Time,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to marshal webhook entry: %w", err)
+	}
+
+	resp, err := w.Client.Post(w.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("unable to deliver webhook entry: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("webhook endpoint returned status %d", resp.StatusCode)
+	}
+	return nil
+}
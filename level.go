@@ -0,0 +1,13 @@
+package logger
+
+// Level defines all possible levels for a log entry to be established as.
+type Level string
+
+const (
+	DisabledLevel Level = "disabled"
+	ErrorLevel    Level = "error"
+	WarnLevel     Level = "warning"
+	InfoLevel     Level = "info"
+	DebugLevel    Level = "debug"
+	TraceLevel    Level = "trace"
+)